@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// CrawlRequest ist der erwartete JSON-Body fuer POST /crawl.
+type CrawlRequest struct {
+	URLs           []string `json:"urls"`
+	DelayMS        int      `json:"delay_ms"`
+	UserAgent      string   `json:"user_agent"`
+	IgnoreRobots   bool     `json:"ignore_robots"`
+	MaxConcurrency int      `json:"max_concurrency"`
+	TimeoutMS      int      `json:"timeout_ms"`
+}
+
+// CrawlResult beschreibt das Ergebnis fuer eine einzelne eingereichte URL.
+type CrawlResult struct {
+	URL          string   `json:"url"`
+	LivalinkURLs []string `json:"livalink_urls,omitempty"`
+	Skipped      string   `json:"skipped,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+const (
+	defaultUserAgent = "LinktreeToLivalinkCrawler/1.0"
+	defaultDelayMS   = 1000
+)
+
+// crawlHandler verarbeitet viele URLs ueber einen beschraenkten Worker-Pool
+// und streamt die Ergebnisse als Newline-Delimited JSON, damit der Client
+// schon waehrend eines laufenden Crawls Fortschritt sieht. Welcher Extractor
+// pro Host greift, bestimmt die uebergebene extractorRegistry.
+func crawlHandler(registry *extractorRegistry, cache responseCache, cacheTTL time.Duration, throttle *hostThrottle) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req CrawlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(req.URLs) == 0 {
+			http.Error(w, "urls must not be empty", http.StatusBadRequest)
+			return
+		}
+
+		start := time.Now()
+		defer func() { crawlDuration.Observe(time.Since(start).Seconds()) }()
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		userAgent := req.UserAgent
+		if userAgent == "" {
+			userAgent = defaultUserAgent
+		}
+		delay := req.DelayMS
+		if delay <= 0 {
+			delay = defaultDelayMS
+		}
+
+		ctx := r.Context()
+		if req.TimeoutMS > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(req.TimeoutMS)*time.Millisecond)
+			defer cancel()
+		}
+
+		noCache := r.URL.Query().Get("no_cache") == "1"
+		fetcher := newPoliteFetcher(userAgent, delay, req.IgnoreRobots, registry, cache, cacheTTL, noCache, throttle)
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+
+		enc := json.NewEncoder(w)
+		runPool(ctx, req.URLs, req.MaxConcurrency, fetcher.crawlOne, func(result CrawlResult) {
+			enc.Encode(result)
+			flusher.Flush()
+		})
+	}
+}
+
+func (f *politeFetcher) crawlOne(ctx context.Context, url string) CrawlResult {
+	result := CrawlResult{URL: url}
+
+	allowed, err := f.allowed(ctx, url)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if !allowed {
+		result.Skipped = "disallowed"
+		return result
+	}
+
+	body, err := f.fetch(ctx, url)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	livalinkURLs, err := extractLivalinkURLs(f.extractors, url, body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.LivalinkURLs = livalinkURLs
+	return result
+}
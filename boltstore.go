@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+)
+
+var jobsBucket = []byte("jobs")
+
+// boltJobStore persistiert Jobs in einer BoltDB-Datei, damit sie einen
+// Neustart des Servers ueberleben. Das Interface ist identisch zu
+// memoryJobStore, sodass beide Backends austauschbar sind.
+type boltJobStore struct {
+	db *bbolt.DB
+}
+
+func newBoltJobStore(path string) (*boltJobStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltJobStore{db: db}, nil
+}
+
+func (s *boltJobStore) Create(job *Job) error {
+	return s.put(job)
+}
+
+func (s *boltJobStore) Update(job *Job) error {
+	existing, err := s.Get(job.ID)
+	if err != nil {
+		return err
+	}
+	_ = existing
+	return s.put(job)
+}
+
+func (s *boltJobStore) put(job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(jobsBucket).Put([]byte(job.ID), data)
+	})
+}
+
+func (s *boltJobStore) Get(id string) (*Job, error) {
+	var job Job
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(jobsBucket).Get([]byte(id))
+		if data == nil {
+			return errJobNotFound
+		}
+		return json.Unmarshal(data, &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (s *boltJobStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		if b.Get([]byte(id)) == nil {
+			return errJobNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
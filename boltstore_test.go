@@ -0,0 +1,77 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBoltJobStoreCRUD(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "jobs.db")
+	s, err := newBoltJobStore(dbPath)
+	if err != nil {
+		t.Fatalf("newBoltJobStore: %v", err)
+	}
+	defer s.db.Close()
+
+	job := &Job{ID: "job-1", Status: JobStatusQueued}
+	if err := s.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != JobStatusQueued {
+		t.Errorf("got status %q, want %q", got.Status, JobStatusQueued)
+	}
+
+	got.Status = JobStatusDone
+	if err := s.Update(got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got2, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get after update: %v", err)
+	}
+	if got2.Status != JobStatusDone {
+		t.Errorf("got status %q after update, want %q", got2.Status, JobStatusDone)
+	}
+
+	if err := s.Delete("job-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get("job-1"); err != errJobNotFound {
+		t.Errorf("expected errJobNotFound after delete, got %v", err)
+	}
+}
+
+func TestBoltJobStorePersistsAcrossReopen(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "jobs.db")
+
+	s1, err := newBoltJobStore(dbPath)
+	if err != nil {
+		t.Fatalf("newBoltJobStore: %v", err)
+	}
+	if err := s1.Create(&Job{ID: "job-1", Status: JobStatusRunning}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := s1.db.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	s2, err := newBoltJobStore(dbPath)
+	if err != nil {
+		t.Fatalf("reopen newBoltJobStore: %v", err)
+	}
+	defer s2.db.Close()
+
+	got, err := s2.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get after reopen: %v", err)
+	}
+	if got.Status != JobStatusRunning {
+		t.Errorf("got status %q after reopen, want %q", got.Status, JobStatusRunning)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheEntry ist der gespeicherte Zustand einer einzelnen gecrawlten URL.
+type cacheEntry struct {
+	Body         string
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+}
+
+func (e *cacheEntry) expired(ttl time.Duration) bool {
+	return time.Since(e.StoredAt) > ttl
+}
+
+// responseCache haelt gecachte HTTP-Antworten, damit wiederholtes Crawlen
+// derselben Seite innerhalb der TTL nicht erneut auf das Ziel zugreift.
+type responseCache interface {
+	get(key string) (*cacheEntry, bool)
+	set(key string, entry *cacheEntry)
+	clear()
+}
+
+// newResponseCache erzeugt das konfigurierte Cache-Backend. backend ist
+// "memory" (Default, LRU mit capacity Eintraegen) oder "file" (dir wird
+// benoetigt).
+func newResponseCache(backend, dir string, capacity int) (responseCache, error) {
+	switch backend {
+	case "", "memory":
+		return newMemoryCache(capacity), nil
+	case "file":
+		return newFileCache(dir)
+	default:
+		return nil, &cacheBackendError{backend: backend}
+	}
+}
+
+type cacheBackendError struct{ backend string }
+
+func (e *cacheBackendError) Error() string {
+	return "unknown cache backend: " + e.backend
+}
+
+const defaultCacheCapacity = 1024
+
+// memoryCache ist ein einfacher, mutexgeschuetzter LRU-Cache.
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *cacheEntry
+}
+
+func newMemoryCache(capacity int) *memoryCache {
+	if capacity <= 0 {
+		capacity = defaultCacheCapacity
+	}
+	return &memoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *memoryCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+func (c *memoryCache) set(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&memoryCacheItem{key: key, entry: entry})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheItem).key)
+		}
+	}
+}
+
+func (c *memoryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ll = list.New()
+	c.items = make(map[string]*list.Element)
+}
+
+// canonicalizeURL normalisiert rawURL purell-artig: Host klein schreiben,
+// Standard-Ports entfernen, Query-Parameter sortieren, Fragment verwerfen.
+func canonicalizeURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	u.Host = strings.TrimSuffix(u.Host, ":80")
+	u.Host = strings.TrimSuffix(u.Host, ":443")
+	u.Fragment = ""
+
+	if u.RawQuery != "" {
+		values := u.Query()
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		sorted := url.Values{}
+		for _, k := range keys {
+			vs := values[k]
+			sort.Strings(vs)
+			for _, v := range vs {
+				sorted.Add(k, v)
+			}
+		}
+		u.RawQuery = sorted.Encode()
+	}
+
+	return u.String(), nil
+}
+
+// cacheKey bildet canonicalizeURL auf einen dateisystem- und mapschluesselsicheren Hash ab.
+func cacheKey(rawURL string) string {
+	canonical, err := canonicalizeURL(rawURL)
+	if err != nil {
+		canonical = rawURL
+	}
+	sum := sha1.Sum([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
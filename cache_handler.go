@@ -0,0 +1,16 @@
+package main
+
+import "net/http"
+
+// handleCacheAdminFunc leert den Response-Cache auf Anfrage, etwa wenn ein
+// Ziel bekanntermassen aktualisiert wurde und sofort neu gecrawlt werden soll.
+func handleCacheAdminFunc(cache responseCache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "only DELETE is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		cache.clear()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"HTTP://Example.COM:80/path", "http://example.com/path"},
+		{"https://example.com:443/path#section", "https://example.com/path"},
+		{"https://example.com/path?b=2&a=1", "https://example.com/path?a=1&b=2"},
+	}
+
+	for _, tt := range tests {
+		got, err := canonicalizeURL(tt.in)
+		if err != nil {
+			t.Fatalf("canonicalizeURL(%q) returned error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("canonicalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCacheKeyIgnoresCanonicallyEquivalentURLs(t *testing.T) {
+	a := cacheKey("https://Example.com:443/path?b=2&a=1")
+	b := cacheKey("https://example.com/path?a=1&b=2#ignored")
+	if a != b {
+		t.Errorf("expected canonically equivalent URLs to share a cache key, got %q and %q", a, b)
+	}
+}
+
+func TestCacheEntryExpired(t *testing.T) {
+	entry := &cacheEntry{StoredAt: time.Now().Add(-2 * time.Second)}
+	if !entry.expired(time.Second) {
+		t.Error("expected entry older than the TTL to be expired")
+	}
+	if entry.expired(time.Minute) {
+		t.Error("expected entry within the TTL to not be expired")
+	}
+}
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	c := newMemoryCache(10)
+
+	if _, ok := c.get("missing"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	c.set("a", &cacheEntry{Body: "a-body"})
+	entry, ok := c.get("a")
+	if !ok || entry.Body != "a-body" {
+		t.Fatalf("expected to get back what was set, got %+v, %v", entry, ok)
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newMemoryCache(2)
+
+	c.set("a", &cacheEntry{Body: "a"})
+	c.set("b", &cacheEntry{Body: "b"})
+	c.get("a") // touch "a" so "b" becomes the least recently used entry
+	c.set("c", &cacheEntry{Body: "c"})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("expected recently touched entry to survive eviction")
+	}
+	if _, ok := c.get("c"); !ok {
+		t.Error("expected newly inserted entry to be present")
+	}
+}
+
+func TestMemoryCacheClear(t *testing.T) {
+	c := newMemoryCache(10)
+	c.set("a", &cacheEntry{Body: "a"})
+	c.clear()
+
+	if _, ok := c.get("a"); ok {
+		t.Error("expected clear to remove all entries")
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const defaultJobWorkers = 4
+
+// dispatcher zieht Jobs aus einer Queue und arbeitet sie mit dem gleichen
+// politeFetcher/runPool-Unterbau ab, der auch /crawl bedient. Laufzeitzustand
+// wie Cancel-Funktionen und SSE-Abonnenten lebt bewusst nur im Prozess-
+// Speicher; der Job selbst (Status/Results) liegt im JobStore.
+type dispatcher struct {
+	store      JobStore
+	extractors *extractorRegistry
+	cache      responseCache
+	cacheTTL   time.Duration
+	throttle   *hostThrottle
+	logger     *slog.Logger
+	queue      chan string
+
+	mu          sync.Mutex
+	cancels     map[string]context.CancelFunc
+	subscribers map[string][]chan CrawlResult
+}
+
+func newDispatcher(store JobStore, extractors *extractorRegistry, cache responseCache, cacheTTL time.Duration, throttle *hostThrottle, logger *slog.Logger, workers int) *dispatcher {
+	if workers <= 0 {
+		workers = defaultJobWorkers
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	d := &dispatcher{
+		store:       store,
+		extractors:  extractors,
+		cache:       cache,
+		cacheTTL:    cacheTTL,
+		throttle:    throttle,
+		logger:      logger,
+		queue:       make(chan string, 1024),
+		cancels:     make(map[string]context.CancelFunc),
+		subscribers: make(map[string][]chan CrawlResult),
+	}
+	for i := 0; i < workers; i++ {
+		go d.worker()
+	}
+	return d
+}
+
+func (d *dispatcher) enqueue(job *Job) {
+	jobQueueDepth.Inc()
+	d.queue <- job.ID
+}
+
+// cancel bricht einen laufenden oder wartenden Job ab. Es liefert false,
+// wenn der Job diesem Prozess nicht (mehr) bekannt ist, etwa weil er schon
+// abgeschlossen ist.
+func (d *dispatcher) cancel(id string) bool {
+	d.mu.Lock()
+	cancel, ok := d.cancels[id]
+	d.mu.Unlock()
+	if !ok {
+		return false
+	}
+	cancel()
+	return true
+}
+
+func (d *dispatcher) subscribe(id string) (<-chan CrawlResult, func()) {
+	ch := make(chan CrawlResult, 16)
+	d.mu.Lock()
+	d.subscribers[id] = append(d.subscribers[id], ch)
+	d.mu.Unlock()
+
+	unsubscribe := func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		subs := d.subscribers[id]
+		for i, s := range subs {
+			if s == ch {
+				d.subscribers[id] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+func (d *dispatcher) publish(id string, result CrawlResult) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, ch := range d.subscribers[id] {
+		select {
+		case ch <- result:
+		default:
+			// Langsamer Abonnent: Ergebnis wird verworfen statt den Worker zu blockieren.
+		}
+	}
+}
+
+func (d *dispatcher) worker() {
+	for id := range d.queue {
+		d.run(id)
+	}
+}
+
+func (d *dispatcher) run(id string) {
+	jobQueueDepth.Dec()
+
+	job, err := d.store.Get(id)
+	if err != nil {
+		return
+	}
+	if job.Status == JobStatusCancelled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = context.WithValue(ctx, loggerKey, d.logger.With("job_id", id))
+	d.mu.Lock()
+	d.cancels[id] = cancel
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.cancels, id)
+		d.mu.Unlock()
+		cancel()
+	}()
+
+	job.Status = JobStatusRunning
+	job.UpdatedAt = time.Now()
+	d.store.Update(job)
+
+	req := job.Request
+	userAgent := req.UserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	delay := req.DelayMS
+	if delay <= 0 {
+		delay = defaultDelayMS
+	}
+	fetcher := newPoliteFetcher(userAgent, delay, req.IgnoreRobots, d.extractors, d.cache, d.cacheTTL, false, d.throttle)
+
+	results := make([]CrawlResult, 0, len(req.URLs))
+	runPool(ctx, req.URLs, req.MaxConcurrency, fetcher.crawlOne, func(result CrawlResult) {
+		results = append(results, result)
+		d.publish(id, result)
+	})
+
+	job, err = d.store.Get(id)
+	if err != nil {
+		return
+	}
+	job.Results = results
+	job.UpdatedAt = time.Now()
+	if ctx.Err() != nil {
+		job.Status = JobStatusCancelled
+	} else {
+		job.Status = JobStatusDone
+	}
+	d.store.Update(job)
+
+	d.mu.Lock()
+	for _, ch := range d.subscribers[id] {
+		close(ch)
+	}
+	delete(d.subscribers, id)
+	d.mu.Unlock()
+}
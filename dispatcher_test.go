@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestDispatcher(t *testing.T) (*dispatcher, JobStore) {
+	t.Helper()
+	store := newMemoryJobStore()
+	d := newDispatcher(store, newExtractorRegistry(), newMemoryCache(defaultCacheCapacity), time.Minute, newHostThrottle(), nil, 1)
+	return d, store
+}
+
+func TestHandleCancelJobOnTerminalJobReturns409AndLeavesResultsUntouched(t *testing.T) {
+	d, store := newTestDispatcher(t)
+
+	job := &Job{ID: "job-1", Status: JobStatusDone, Results: []CrawlResult{{URL: "https://example.com"}}}
+	if err := store.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/jobs/job-1", nil)
+	w := httptest.NewRecorder()
+	handleCancelJob(d, w, req, "job-1")
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for an already-terminal job, got %d", w.Code)
+	}
+
+	got, err := store.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != JobStatusDone {
+		t.Errorf("expected status to stay %q, got %q", JobStatusDone, got.Status)
+	}
+	if len(got.Results) != 1 || got.Results[0].URL != "https://example.com" {
+		t.Errorf("expected results to be left untouched, got %+v", got.Results)
+	}
+}
+
+// TestCancellingQueuedJobDecrementsQueueDepthAndDispatcherNoOps deckt den in
+// 2569dbb/df61bbf behobenen Race ab: ein Job, der noch in der Queue wartet,
+// wird abgebrochen, bevor ein Worker ihn zieht. dispatcher.run muss trotzdem
+// den Gauge dekrementieren und darf den bereits abgebrochenen Job nicht
+// erneut ausfuehren.
+func TestCancellingQueuedJobDecrementsQueueDepthAndDispatcherNoOps(t *testing.T) {
+	d, store := newTestDispatcher(t)
+
+	job := &Job{ID: "job-2", Status: JobStatusQueued}
+	if err := store.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	jobQueueDepth.Inc() // entspricht dem Inc() in d.enqueue beim Einreihen
+	before := testutil.ToFloat64(jobQueueDepth)
+
+	req := httptest.NewRequest(http.MethodDelete, "/jobs/job-2", nil)
+	w := httptest.NewRecorder()
+	handleCancelJob(d, w, req, "job-2")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+
+	// Simuliert den Worker, der den bereits abgebrochenen Job aus der Queue zieht.
+	d.run("job-2")
+
+	if after := testutil.ToFloat64(jobQueueDepth); after != before-1 {
+		t.Errorf("expected jobQueueDepth to be decremented by run, before=%v after=%v", before, after)
+	}
+
+	got, err := store.Get("job-2")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != JobStatusCancelled {
+		t.Errorf("expected job to stay cancelled, got %q", got.Status)
+	}
+	if got.Results != nil {
+		t.Errorf("expected dispatcher to no-op on an already-cancelled job, got results %+v", got.Results)
+	}
+}
+
+// TestDispatcherRunPropagatesJobLoggerIntoContext stellt sicher, dass
+// loggerFromContext innerhalb eines Jobs den per-Job-Logger (mit job_id)
+// liefert statt immer auf slog.Default() zurueckzufallen.
+func TestDispatcherRunPropagatesJobLoggerIntoContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	store := newMemoryJobStore()
+	d := newDispatcher(store, newExtractorRegistry(), newMemoryCache(defaultCacheCapacity), time.Minute, newHostThrottle(), logger, 1)
+
+	job := &Job{
+		ID:     "job-3",
+		Status: JobStatusQueued,
+		Request: CrawlRequest{
+			URLs:           []string{"http://127.0.0.1:1/unreachable"},
+			MaxConcurrency: 1,
+			IgnoreRobots:   true,
+		},
+	}
+	if err := store.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	d.run("job-3")
+
+	if !strings.Contains(buf.String(), `"job_id":"job-3"`) {
+		t.Errorf("expected the job's fetch-failed log line to carry job_id, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractorRule konfiguriert, welcher CSS-Selektor und welches Attribut fuer
+// einen Host die Linktree-Links liefert, z.B.
+// {"host":"linktr.ee","selector":"a[data-testid=LinkButton]","attr":"href"}.
+type ExtractorRule struct {
+	Host     string `json:"host"`
+	Selector string `json:"selector"`
+	Attr     string `json:"attr"`
+}
+
+// Extractor liest Links aus einem bereits geparsten HTML-Dokument.
+type Extractor interface {
+	Extract(doc *goquery.Document) []string
+}
+
+type selectorExtractor struct {
+	selector string
+	attr     string
+}
+
+func (e *selectorExtractor) Extract(doc *goquery.Document) []string {
+	var found []string
+	doc.Find(e.selector).Each(func(_ int, s *goquery.Selection) {
+		if val, ok := s.Attr(e.attr); ok {
+			found = append(found, val)
+		}
+	})
+	return found
+}
+
+// genericExtractor ist der Fallback fuer Hosts ohne eigene Regel: er sammelt
+// jedes <a href>, genau wie der urspruengliche Regex-Scanner.
+type genericExtractor struct{}
+
+func (genericExtractor) Extract(doc *goquery.Document) []string {
+	var found []string
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		if val, ok := s.Attr("href"); ok {
+			found = append(found, val)
+		}
+	})
+	return found
+}
+
+// extractorRegistry verwaltet die pro Host konfigurierten Extraktionsregeln
+// und kann zur Laufzeit ueber POST /extractors erweitert werden.
+type extractorRegistry struct {
+	mu    sync.RWMutex
+	rules []ExtractorRule
+}
+
+func newExtractorRegistry() *extractorRegistry {
+	return &extractorRegistry{}
+}
+
+func (r *extractorRegistry) add(rule ExtractorRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rule)
+}
+
+// forHost liefert den Extractor fuer den gegebenen Host, oder den generischen
+// Fallback, wenn keine Regel passt. Zuletzt hinzugefuegte Regeln gewinnen bei
+// mehreren Treffern fuer denselben Host.
+func (r *extractorRegistry) forHost(host string) Extractor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		rule := r.rules[i]
+		if matchesHost(rule.Host, host) {
+			attr := rule.Attr
+			if attr == "" {
+				attr = "href"
+			}
+			return &selectorExtractor{selector: rule.Selector, attr: attr}
+		}
+	}
+	return genericExtractor{}
+}
+
+func matchesHost(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+	if strings.HasPrefix(pattern, "*.") {
+		return host == pattern[2:] || strings.HasSuffix(host, pattern[1:])
+	}
+	return pattern == host
+}
+
+var livalinkHostPart = regexp.MustCompile(`livalink\.`)
+
+func isLivalinkURL(href string) bool {
+	return livalinkHostPart.MatchString(href)
+}
+
+// extractLivalinkURLs parst html, wendet den fuer rawURL passenden Extractor
+// an und filtert das Ergebnis auf Links, die auf Livalink verweisen.
+func extractLivalinkURLs(registry *extractorRegistry, rawURL, html string) ([]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, err
+	}
+
+	extractor := registry.forHost(u.Host)
+	var found []string
+	for _, href := range extractor.Extract(doc) {
+		if isLivalinkURL(href) {
+			found = append(found, href)
+		}
+	}
+	return found, nil
+}
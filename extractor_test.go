@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+func TestMatchesHost(t *testing.T) {
+	tests := []struct {
+		pattern, host string
+		want          bool
+	}{
+		{"linktr.ee", "linktr.ee", true},
+		{"linktr.ee", "LINKTR.EE", true},
+		{"linktr.ee", "sub.linktr.ee", false},
+		{"*.linktr.ee", "sub.linktr.ee", true},
+		{"*.linktr.ee", "linktr.ee", true},
+		{"*.linktr.ee", "other.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesHost(tt.pattern, tt.host); got != tt.want {
+			t.Errorf("matchesHost(%q, %q) = %v, want %v", tt.pattern, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestExtractorRegistryFallsBackToGeneric(t *testing.T) {
+	r := newExtractorRegistry()
+	if _, ok := r.forHost("linktr.ee").(genericExtractor); !ok {
+		t.Error("expected generic fallback extractor when no rule matches")
+	}
+}
+
+func TestExtractorRegistryMostRecentRuleWins(t *testing.T) {
+	r := newExtractorRegistry()
+	r.add(ExtractorRule{Host: "linktr.ee", Selector: "a.old", Attr: "href"})
+	r.add(ExtractorRule{Host: "linktr.ee", Selector: "a.new", Attr: "href"})
+
+	got, ok := r.forHost("linktr.ee").(*selectorExtractor)
+	if !ok {
+		t.Fatalf("expected a *selectorExtractor, got %T", r.forHost("linktr.ee"))
+	}
+	if got.selector != "a.new" {
+		t.Errorf("expected the most recently added rule to win, got selector %q", got.selector)
+	}
+}
+
+func TestExtractLivalinkURLsWithSelectorRule(t *testing.T) {
+	r := newExtractorRegistry()
+	r.add(ExtractorRule{Host: "linktr.ee", Selector: "a[data-testid=LinkButton]", Attr: "href"})
+
+	html := `<html><body>
+		<a data-testid="LinkButton" href="https://livalink.example/profile">link</a>
+		<a href="https://example.com/ignored">not matched by selector</a>
+	</body></html>`
+
+	got, err := extractLivalinkURLs(r, "https://linktr.ee/someone", html)
+	if err != nil {
+		t.Fatalf("extractLivalinkURLs: %v", err)
+	}
+	if len(got) != 1 || got[0] != "https://livalink.example/profile" {
+		t.Errorf("got %v, want exactly the livalink link matched by the selector", got)
+	}
+}
+
+func TestExtractLivalinkURLsFallsBackToGenericScan(t *testing.T) {
+	r := newExtractorRegistry()
+
+	html := `<html><body>
+		<a href="https://livalink.example/profile">link</a>
+		<a href="https://example.com/other">other</a>
+	</body></html>`
+
+	got, err := extractLivalinkURLs(r, "https://unknown-host.example/page", html)
+	if err != nil {
+		t.Fatalf("extractLivalinkURLs: %v", err)
+	}
+	if len(got) != 1 || got[0] != "https://livalink.example/profile" {
+		t.Errorf("got %v, want exactly the livalink link found by the generic fallback", got)
+	}
+}
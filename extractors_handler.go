@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// handleAddExtractorFunc registriert eine neue ExtractorRule zur Laufzeit,
+// ohne dass der Server neu gestartet werden muss.
+func handleAddExtractorFunc(registry *extractorRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var rule ExtractorRule
+		if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if rule.Host == "" || rule.Selector == "" {
+			http.Error(w, "host and selector are required", http.StatusBadRequest)
+			return
+		}
+
+		registry.add(rule)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// fileCache persistiert cacheEntry-Werte als JSON-Dateien in einem
+// Verzeichnis, benannt nach cacheKey. Damit ueberlebt der Cache Neustarts.
+type fileCache struct {
+	dir string
+}
+
+func newFileCache(dir string) (*fileCache, error) {
+	if dir == "" {
+		return nil, &cacheBackendError{backend: "file (no dir configured)"}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileCache{dir: dir}, nil
+}
+
+func (c *fileCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *fileCache) get(key string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *fileCache) set(key string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(key), data, 0o644)
+}
+
+func (c *fileCache) clear() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		_ = os.Remove(filepath.Join(c.dir, e.Name()))
+	}
+}
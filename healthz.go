@@ -0,0 +1,22 @@
+package main
+
+import "net/http"
+
+// handleHealthz ist die Liveness-Probe: solange der Prozess antwortet, ist er gesund.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+// handleReadyzFunc ist die Readiness-Probe: sie prueft, ob der JobStore
+// erreichbar ist, bevor der Server Traffic bekommen sollte.
+func handleReadyzFunc(store JobStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := store.Get("__readyz_probe__"); err != nil && err != errJobNotFound {
+			http.Error(w, "not ready: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	}
+}
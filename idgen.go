@@ -0,0 +1,14 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newID erzeugt eine zufaellige, URL-sichere ID, die sowohl fuer Jobs als
+// auch fuer Request-IDs im Logging verwendet wird.
+func newID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// handleCreateJob nimmt einen CrawlRequest entgegen, legt dafuer sofort
+// einen Job an und gibt die Job-ID zurueck; die eigentliche Arbeit passiert
+// asynchron im dispatcher.
+func handleCreateJob(d *dispatcher, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req CrawlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.URLs) == 0 {
+		http.Error(w, "urls must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:        newID(),
+		Request:   req,
+		Status:    JobStatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := d.store.Create(job); err != nil {
+		http.Error(w, "could not create job: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	d.enqueue(job)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleGetJob gibt Status, Fortschritt und bisherige Ergebnisse eines Jobs zurueck.
+func handleGetJob(d *dispatcher, w http.ResponseWriter, r *http.Request, id string) {
+	job, err := d.store.Get(id)
+	if err != nil {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// handleStreamJob sendet neu entdeckte Livalink-URLs als Server-Sent Events,
+// sobald der dispatcher sie produziert.
+func handleStreamJob(d *dispatcher, w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := d.store.Get(id); err != nil {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	results, unsubscribe := d.subscribe(id)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleCancelJob bricht einen wartenden oder laufenden Job ab. Ein bereits
+// abgeschlossener Job wird nicht angefasst, damit sein Endstatus und seine
+// Results erhalten bleiben.
+func handleCancelJob(d *dispatcher, w http.ResponseWriter, r *http.Request, id string) {
+	job, err := d.store.Get(id)
+	if err != nil {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	if isTerminalJobStatus(job.Status) {
+		http.Error(w, "job is already "+string(job.Status), http.StatusConflict)
+		return
+	}
+
+	d.cancel(id)
+
+	job.Status = JobStatusCancelled
+	job.UpdatedAt = time.Now()
+	d.store.Update(job)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// jobsRouter verteilt Requests unter /jobs/ anhand von Methode und Pfad, da
+// der Standard-ServeMux keine Pfad-Parameter unterstuetzt.
+func jobsRouter(d *dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/jobs")
+		rest = strings.Trim(rest, "/")
+
+		if rest == "" {
+			handleCreateJob(d, w, r)
+			return
+		}
+
+		parts := strings.SplitN(rest, "/", 2)
+		id := parts[0]
+
+		if len(parts) == 2 && parts[1] == "stream" {
+			handleStreamJob(d, w, r, id)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			handleGetJob(d, w, r, id)
+		case http.MethodDelete:
+			handleCancelJob(d, w, r, id)
+		default:
+			http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	}
+}
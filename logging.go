@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type ctxKey string
+
+const (
+	requestIDKey ctxKey = "request_id"
+	loggerKey    ctxKey = "logger"
+)
+
+// withRequestLogging vergibt jedem eingehenden Request eine ID, loggt Start
+// und Ende strukturiert und legt Logger sowie ID im Request-Context ab, damit
+// sie bis in die Crawler-Goroutinen durchgereicht werden koennen.
+func withRequestLogging(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := newID()
+		reqLogger := logger.With("request_id", id)
+
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		ctx = context.WithValue(ctx, loggerKey, reqLogger)
+
+		start := time.Now()
+		reqLogger.Info("request started", "method", r.Method, "path", r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(ctx))
+		reqLogger.Info("request finished", "method", r.Method, "path", r.URL.Path, "duration_ms", time.Since(start).Milliseconds())
+	})
+}
+
+// loggerFromContext liefert den Request-Logger, falls der Context einer aus
+// withRequestLogging ist, sonst slog.Default().
+func loggerFromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
@@ -1,15 +1,65 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"flag"
+	"log/slog"
 	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+const defaultCacheTTL = 10 * time.Minute
+
+// getenv liefert den Wert der Umgebungsvariable key, oder fallback, wenn sie
+// nicht gesetzt ist. Flags haben Vorrang vor der Umgebungsvariable, da ihr
+// Default-Wert hieraus gelesen wird.
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
 func main() {
-	// Starte den API-Server und registriere die Endpunkte
-	http.HandleFunc("/crawl", handleCrawlRequest) // Diese Funktion ist in api.go definiert
+	jobStoreBackend := flag.String("job-store-backend", getenv("JOB_STORE_BACKEND", "memory"), `job store backend: "memory" or "bolt"`)
+	jobStorePath := flag.String("job-store-path", getenv("JOB_STORE_PATH", ""), "path to the bolt database file (required for -job-store-backend=bolt)")
+	cacheBackend := flag.String("cache-backend", getenv("CACHE_BACKEND", "memory"), `response cache backend: "memory" or "file"`)
+	cacheDir := flag.String("cache-dir", getenv("CACHE_DIR", ""), "directory for the on-disk response cache (required for -cache-backend=file)")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	store, err := newJobStore(*jobStoreBackend, *jobStorePath)
+	if err != nil {
+		logger.Error("could not create job store", "error", err)
+		os.Exit(1)
+	}
+	cache, err := newResponseCache(*cacheBackend, *cacheDir, defaultCacheCapacity)
+	if err != nil {
+		logger.Error("could not create response cache", "error", err)
+		os.Exit(1)
+	}
+	extractors := newExtractorRegistry()
+	throttle := newHostThrottle()
+	d := newDispatcher(store, extractors, cache, defaultCacheTTL, throttle, logger, defaultJobWorkers)
+
+	mux := http.NewServeMux()
+	// Registriere die Endpunkte
+	mux.HandleFunc("/crawl", crawlHandler(extractors, cache, defaultCacheTTL, throttle)) // Diese Funktion ist in api.go definiert
+	mux.HandleFunc("/jobs", jobsRouter(d))                                               // Diese Funktion ist in jobs.go definiert
+	mux.HandleFunc("/jobs/", jobsRouter(d))
+	mux.HandleFunc("/extractors", handleAddExtractorFunc(extractors)) // Diese Funktion ist in extractors_handler.go definiert
+	mux.HandleFunc("/cache", handleCacheAdminFunc(cache))             // Diese Funktion ist in cache_handler.go definiert
+	mux.HandleFunc("/healthz", handleHealthz)
+	mux.HandleFunc("/readyz", handleReadyzFunc(store))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	srv := &http.Server{
+		Addr:    ":8080",
+		Handler: withRequestLogging(logger, mux),
+	}
 
-	fmt.Println("Server is running on :8080...")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	runServer(srv, logger)
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	crawlDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "crawler_crawl_duration_seconds",
+		Help: "Dauer eines kompletten POST /crawl Requests.",
+	})
+
+	hostFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "crawler_host_fetch_duration_seconds",
+		Help: "Latenz einzelner HTTP-Fetches, aufgeschluesselt nach Host.",
+	}, []string{"host"})
+
+	httpStatusTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "crawler_fetch_http_status_total",
+		Help: "Anzahl der HTTP-Statuscodes von gecrawlten Zielseiten.",
+	}, []string{"status"})
+
+	jobQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "crawler_job_queue_depth",
+		Help: "Anzahl der Jobs, die aktuell auf Bearbeitung durch den dispatcher warten.",
+	})
+)
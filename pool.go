@@ -0,0 +1,56 @@
+package main
+
+import "context"
+
+const defaultMaxConcurrency = 8
+
+// runPool verteilt urls auf maxConcurrency Worker-Goroutinen und ruft fuer
+// jedes Ergebnis emit auf, sobald es fertig ist. Die Reihenfolge der emit-
+// Aufrufe entspricht nicht der Reihenfolge von urls.
+func runPool(ctx context.Context, urls []string, maxConcurrency int, work func(context.Context, string) CrawlResult, emit func(CrawlResult)) {
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	jobs := make(chan string)
+	results := make(chan CrawlResult)
+	done := make(chan struct{})
+
+	for i := 0; i < maxConcurrency; i++ {
+		go func() {
+			for u := range jobs {
+				r := work(ctx, u)
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, u := range urls {
+			select {
+			case jobs <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(done)
+		for range urls {
+			select {
+			case r := <-results:
+				emit(r)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	<-done
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunPoolProcessesAllURLs(t *testing.T) {
+	urls := []string{"a", "b", "c", "d", "e"}
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	runPool(context.Background(), urls, 2, func(_ context.Context, url string) CrawlResult {
+		return CrawlResult{URL: url}
+	}, func(result CrawlResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[result.URL] = true
+	})
+
+	for _, u := range urls {
+		if !seen[u] {
+			t.Errorf("url %q was never emitted", u)
+		}
+	}
+}
+
+func TestRunPoolRespectsMaxConcurrency(t *testing.T) {
+	urls := []string{"a", "b", "c", "d", "e", "f"}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	runPool(context.Background(), urls, 2, func(_ context.Context, _ string) CrawlResult {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return CrawlResult{}
+	}, func(CrawlResult) {})
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent workers, saw %d", maxInFlight)
+	}
+}
+
+func TestRunPoolStopsOnCancellation(t *testing.T) {
+	urls := make([]string, 50)
+	for i := range urls {
+		urls[i] = "u"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var mu sync.Mutex
+	var emitted int
+	runPool(ctx, urls, 4, func(ctx context.Context, url string) CrawlResult {
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+		}
+		return CrawlResult{URL: url}
+	}, func(CrawlResult) {
+		mu.Lock()
+		emitted++
+		mu.Unlock()
+	})
+
+	if emitted == len(urls) {
+		t.Error("expected the deadline to stop the pool before processing every url")
+	}
+}
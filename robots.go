@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// hostThrottle haelt den robots.txt-Cache und den Crawl-Delay-Zustand pro
+// Host. Im Gegensatz zu politeFetcher lebt es fuer die gesamte Laufzeit des
+// Servers und wird von allen /crawl-Requests und Jobs geteilt (siehe
+// main.go) - sonst wuerden zwei ueberlappende Requests auf denselben Host
+// sich gegenseitig nicht drosseln und robots.txt bei jedem Aufruf neu holen.
+type hostThrottle struct {
+	mu          sync.Mutex
+	robotsCache map[string]*robotstxt.RobotsData
+	lastFetch   map[string]time.Time
+	hostLocks   map[string]*sync.Mutex
+}
+
+func newHostThrottle() *hostThrottle {
+	return &hostThrottle{
+		robotsCache: make(map[string]*robotstxt.RobotsData),
+		lastFetch:   make(map[string]time.Time),
+		hostLocks:   make(map[string]*sync.Mutex),
+	}
+}
+
+// lockForHost liefert den (ggf. neu angelegten) Mutex, der Fetches gegen
+// denselben Host serialisiert, waehrend mehrere Hosts parallel laufen duerfen.
+func (t *hostThrottle) lockForHost(host string) *sync.Mutex {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lock, ok := t.hostLocks[host]
+	if !ok {
+		lock = &sync.Mutex{}
+		t.hostLocks[host] = lock
+	}
+	return lock
+}
+
+func (t *hostThrottle) robotsFor(ctx context.Context, client *http.Client, u *url.URL) (*robotstxt.RobotsData, error) {
+	host := u.Scheme + "://" + u.Host
+
+	t.mu.Lock()
+	if cached, ok := t.robotsCache[host]; ok {
+		t.mu.Unlock()
+		return cached, nil
+	}
+	t.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	robots, err := robotstxt.FromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.robotsCache[host] = robots
+	t.mu.Unlock()
+
+	return robots, nil
+}
+
+func (t *hostThrottle) wait(ctx context.Context, host string, delay time.Duration) error {
+	t.mu.Lock()
+	last, seen := t.lastFetch[host]
+	t.mu.Unlock()
+
+	if seen {
+		if wait := delay - time.Since(last); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	t.mu.Lock()
+	t.lastFetch[host] = time.Now()
+	t.mu.Unlock()
+	return nil
+}
+
+// politeFetcher holt Seiten ueber HTTP und haelt sich dabei an robots.txt
+// sowie einen konfigurierbaren Crawl-Delay pro Host. Robots-Cache und
+// Rate-Limiter selbst leben im geteilten throttle, nicht im Fetcher.
+type politeFetcher struct {
+	userAgent    string
+	delay        time.Duration
+	ignoreRobots bool
+	client       *http.Client
+	extractors   *extractorRegistry
+	cache        responseCache
+	cacheTTL     time.Duration
+	noCache      bool
+	throttle     *hostThrottle
+}
+
+func newPoliteFetcher(userAgent string, delayMS int, ignoreRobots bool, extractors *extractorRegistry, cache responseCache, cacheTTL time.Duration, noCache bool, throttle *hostThrottle) *politeFetcher {
+	return &politeFetcher{
+		userAgent:    userAgent,
+		delay:        time.Duration(delayMS) * time.Millisecond,
+		ignoreRobots: ignoreRobots,
+		client:       &http.Client{Timeout: 15 * time.Second},
+		extractors:   extractors,
+		cache:        cache,
+		cacheTTL:     cacheTTL,
+		noCache:      noCache,
+		throttle:     throttle,
+	}
+}
+
+// allowed prueft, ob targetURL laut robots.txt des Hosts gecrawlt werden darf.
+func (f *politeFetcher) allowed(ctx context.Context, targetURL string) (bool, error) {
+	if f.ignoreRobots {
+		return true, nil
+	}
+
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return false, fmt.Errorf("invalid url: %w", err)
+	}
+
+	robots, err := f.throttle.robotsFor(ctx, f.client, u)
+	if err != nil {
+		// Kein erreichbares robots.txt heisst: alles ist erlaubt.
+		return true, nil
+	}
+
+	group := robots.FindGroup(f.userAgent)
+	return group.Test(u.Path), nil
+}
+
+// fetch holt die Seite und wartet davor so lange, bis der Crawl-Delay fuer
+// den jeweiligen Host verstrichen ist. Ist ein frischer Cache-Eintrag
+// vorhanden, entfaellt der Netzwerk-Zugriff komplett; bei einem abgelaufenen
+// Eintrag wird per If-None-Match/If-Modified-Since revalidiert.
+func (f *politeFetcher) fetch(ctx context.Context, targetURL string) (string, error) {
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+
+	key := cacheKey(targetURL)
+	var cached *cacheEntry
+	if f.cache != nil && !f.noCache {
+		if entry, ok := f.cache.get(key); ok {
+			cached = entry
+			if !entry.expired(f.cacheTTL) {
+				return entry.Body, nil
+			}
+		}
+	}
+
+	hostLock := f.throttle.lockForHost(u.Host)
+	hostLock.Lock()
+	defer hostLock.Unlock()
+
+	if err := f.throttle.wait(ctx, u.Host, f.delay); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	start := time.Now()
+	resp, err := f.client.Do(req)
+	hostFetchDuration.WithLabelValues(u.Host).Observe(time.Since(start).Seconds())
+	if err != nil {
+		loggerFromContext(ctx).Warn("fetch failed", "url", targetURL, "error", err)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	httpStatusTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached.StoredAt = time.Now()
+		if f.cache != nil {
+			f.cache.set(key, cached)
+		}
+		return cached.Body, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d for %s", resp.StatusCode, targetURL)
+	}
+
+	body := make([]byte, 0, 64*1024)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		body = append(body, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+
+	if f.cache != nil {
+		f.cache.set(key, &cacheEntry{
+			Body:         string(body),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			StoredAt:     time.Now(),
+		})
+	}
+
+	return string(body), nil
+}
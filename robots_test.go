@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHostThrottleWaitEnforcesDelay(t *testing.T) {
+	th := newHostThrottle()
+
+	start := time.Now()
+	if err := th.wait(context.Background(), "example.com", 0); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	if err := th.wait(context.Background(), "example.com", 30*time.Millisecond); err != nil {
+		t.Fatalf("second wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected wait to enforce the crawl delay, only %v elapsed", elapsed)
+	}
+}
+
+func TestHostThrottleWaitRespectsCancellation(t *testing.T) {
+	th := newHostThrottle()
+	if err := th.wait(context.Background(), "example.com", 0); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	err := th.wait(ctx, "example.com", time.Hour)
+	if err == nil {
+		t.Fatal("expected wait to return an error when the context is cancelled before the delay elapses")
+	}
+}
+
+func TestPoliteFetcherAllowedRespectsRobotsDisallow(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := newPoliteFetcher("test-agent", 0, false, newExtractorRegistry(), nil, time.Minute, true, newHostThrottle())
+
+	allowed, err := f.allowed(context.Background(), srv.URL+"/private/page")
+	if err != nil {
+		t.Fatalf("allowed: %v", err)
+	}
+	if allowed {
+		t.Error("expected /private/page to be disallowed by robots.txt")
+	}
+
+	allowed, err = f.allowed(context.Background(), srv.URL+"/public/page")
+	if err != nil {
+		t.Fatalf("allowed: %v", err)
+	}
+	if !allowed {
+		t.Error("expected /public/page to be allowed by robots.txt")
+	}
+}
+
+func TestPoliteFetcherAllowedIgnoresRobotsWhenConfigured(t *testing.T) {
+	f := newPoliteFetcher("test-agent", 0, true, newExtractorRegistry(), nil, time.Minute, true, newHostThrottle())
+
+	allowed, err := f.allowed(context.Background(), "http://example.invalid/private")
+	if err != nil {
+		t.Fatalf("allowed: %v", err)
+	}
+	if !allowed {
+		t.Error("expected ignoreRobots to allow everything")
+	}
+}
+
+func TestHostThrottleRobotsForIsCachedAcrossCalls(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("User-agent: *\nDisallow:\n"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	th := newHostThrottle()
+	f1 := newPoliteFetcher("test-agent", 0, false, newExtractorRegistry(), nil, time.Minute, true, th)
+	f2 := newPoliteFetcher("test-agent", 0, false, newExtractorRegistry(), nil, time.Minute, true, th)
+
+	if _, err := f1.allowed(context.Background(), srv.URL+"/a"); err != nil {
+		t.Fatalf("allowed (f1): %v", err)
+	}
+	if _, err := f2.allowed(context.Background(), srv.URL+"/b"); err != nil {
+		t.Fatalf("allowed (f2): %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected robots.txt to be fetched once and shared via the throttle, got %d fetches", requests)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// JobStatus beschreibt den Lebenszyklus eines Jobs.
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusDone      JobStatus = "done"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// isTerminalJobStatus meldet, ob ein Job in diesem Status nicht mehr
+// abgebrochen oder erneut abgeschlossen werden kann.
+func isTerminalJobStatus(status JobStatus) bool {
+	switch status {
+	case JobStatusDone, JobStatusFailed, JobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Job ist der persistierte Zustand eines einzelnen /jobs-Auftrags.
+type Job struct {
+	ID        string        `json:"id"`
+	Request   CrawlRequest  `json:"request"`
+	Status    JobStatus     `json:"status"`
+	Results   []CrawlResult `json:"results"`
+	Error     string        `json:"error,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+var errJobNotFound = errors.New("job not found")
+
+// JobStore persistiert Jobs. Der Default ist eine In-Memory-Implementierung;
+// boltJobStore legt den gleichen Zustand zusaetzlich auf Platte ab, damit
+// Jobs einen Neustart ueberleben.
+type JobStore interface {
+	Create(job *Job) error
+	Get(id string) (*Job, error)
+	Update(job *Job) error
+	Delete(id string) error
+}
+
+// newJobStore erzeugt den konfigurierten Store-Backend. backend ist "memory"
+// (Default) oder "bolt"; path wird nur fuer "bolt" benoetigt.
+func newJobStore(backend, path string) (JobStore, error) {
+	switch backend {
+	case "", "memory":
+		return newMemoryJobStore(), nil
+	case "bolt":
+		return newBoltJobStore(path)
+	default:
+		return nil, errors.New("unknown job store backend: " + backend)
+	}
+}
+
+type memoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{jobs: make(map[string]*Job)}
+}
+
+func (s *memoryJobStore) Create(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = cloneJob(job)
+	return nil
+}
+
+func (s *memoryJobStore) Get(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, errJobNotFound
+	}
+	return cloneJob(job), nil
+}
+
+func (s *memoryJobStore) Update(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.ID]; !ok {
+		return errJobNotFound
+	}
+	s.jobs[job.ID] = cloneJob(job)
+	return nil
+}
+
+func (s *memoryJobStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return errJobNotFound
+	}
+	delete(s.jobs, id)
+	return nil
+}
+
+func cloneJob(job *Job) *Job {
+	data, err := json.Marshal(job)
+	if err != nil {
+		// Jobs enthalten nur einfache, serialisierbare Felder.
+		panic(err)
+	}
+	clone := &Job{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		panic(err)
+	}
+	return clone
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryJobStoreCRUD(t *testing.T) {
+	s := newMemoryJobStore()
+
+	job := &Job{ID: "job-1", Status: JobStatusQueued, CreatedAt: time.Now()}
+	if err := s.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != JobStatusQueued {
+		t.Errorf("got status %q, want %q", got.Status, JobStatusQueued)
+	}
+
+	got.Status = JobStatusDone
+	if err := s.Update(got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got2, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get after update: %v", err)
+	}
+	if got2.Status != JobStatusDone {
+		t.Errorf("got status %q after update, want %q", got2.Status, JobStatusDone)
+	}
+
+	if err := s.Delete("job-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get("job-1"); err != errJobNotFound {
+		t.Errorf("expected errJobNotFound after delete, got %v", err)
+	}
+}
+
+func TestMemoryJobStoreGetReturnsACopy(t *testing.T) {
+	s := newMemoryJobStore()
+	job := &Job{ID: "job-1", Status: JobStatusQueued}
+	if err := s.Create(job); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got.Status = JobStatusFailed
+
+	again, err := s.Get("job-1")
+	if err != nil {
+		t.Fatalf("Get again: %v", err)
+	}
+	if again.Status != JobStatusQueued {
+		t.Errorf("mutating a returned job leaked into the store: got %q, want %q", again.Status, JobStatusQueued)
+	}
+}
+
+func TestMemoryJobStoreUpdateMissingJob(t *testing.T) {
+	s := newMemoryJobStore()
+	err := s.Update(&Job{ID: "does-not-exist"})
+	if err != errJobNotFound {
+		t.Errorf("expected errJobNotFound, got %v", err)
+	}
+}